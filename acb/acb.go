@@ -0,0 +1,294 @@
+// Package acb decodes and encodes Adobe Color Book (.acb) files, sharing
+// the Color and Group types from the parent ase package. A color book is a
+// single named Group of spot colors plus a short name/code per entry.
+//
+// ACB File Spec http://www.selapa.net/swatches/colors/fileformats.php#adobe_color_book
+package acb
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"unicode/utf16"
+
+	"github.com/assassindesign/ase"
+)
+
+var (
+	ErrInvalidFile       = errors.New("acb: file not a color book")
+	ErrInvalidColorSpace = errors.New("acb: unsupported color space")
+	ErrMixedColorSpace   = errors.New("acb: a color book can only hold one color model")
+)
+
+// colorSpace ids, as used by Adobe's ACB format.
+const (
+	spaceRGB       = 0
+	spaceCMYK      = 2
+	spaceLab       = 7
+	spaceGrayscale = 8
+)
+
+func init() {
+	ase.RegisterFormat("8BCB", Decode)
+}
+
+// Decode reads an Adobe Color Book into a single-Group ase.ASE named after
+// the book.
+func Decode(r io.Reader) (a ase.ASE, err error) {
+	var signature [4]byte
+	if err = binary.Read(r, binary.BigEndian, &signature); err != nil {
+		return
+	}
+	if string(signature[:]) != "8BCB" {
+		return a, ErrInvalidFile
+	}
+
+	var version, identifier uint16
+	if err = binary.Read(r, binary.BigEndian, &version); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &identifier); err != nil {
+		return
+	}
+
+	bookName, err := readString(r)
+	if err != nil {
+		return
+	}
+	// prefix and suffix are concatenated around each color's code when
+	// Photoshop displays it; this package only needs the raw name.
+	if _, err = readString(r); err != nil {
+		return
+	}
+	if _, err = readString(r); err != nil {
+		return
+	}
+
+	var colorCount, pageSize, pageSelectorOffset, space uint16
+	if err = binary.Read(r, binary.BigEndian, &colorCount); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &pageSize); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &pageSelectorOffset); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &space); err != nil {
+		return
+	}
+
+	group := ase.Group{Name: bookName}
+	for i := uint16(0); i < colorCount; i++ {
+		c, err := readColor(r, space)
+		if err != nil {
+			return a, err
+		}
+		group.Colors = append(group.Colors, c)
+	}
+
+	a.Groups = append(a.Groups, group)
+	return a, nil
+}
+
+// Encode writes a as a single color book named after its first Group (or
+// "Colors", if a has none). Top-level Colors, if any, are appended after
+// that Group's own.
+func Encode(a ase.ASE, w io.Writer) (err error) {
+	name := "Colors"
+	var colors []ase.Color
+	if len(a.Groups) > 0 {
+		name = a.Groups[0].Name
+		colors = append(colors, a.Groups[0].Colors...)
+	}
+	colors = append(colors, a.Colors...)
+
+	space, err := commonColorSpace(colors)
+	if err != nil {
+		return
+	}
+
+	if err = binary.Write(w, binary.BigEndian, []byte("8BCB")); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint16(1)); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint16(0)); err != nil {
+		return
+	}
+	if err = writeString(w, name); err != nil {
+		return
+	}
+	if err = writeString(w, ""); err != nil {
+		return
+	}
+	if err = writeString(w, ""); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint16(len(colors))); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint16(1)); err != nil { // pageSize
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint16(0)); err != nil { // pageSelectorOffset
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, space); err != nil {
+		return
+	}
+
+	for _, c := range colors {
+		if err = writeColor(w, c); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func readColor(r io.Reader, space uint16) (c ase.Color, err error) {
+	if c.Name, err = readString(r); err != nil {
+		return
+	}
+
+	var code [6]byte
+	if err = binary.Read(r, binary.BigEndian, &code); err != nil {
+		return
+	}
+
+	switch space {
+	case spaceRGB:
+		c.Model = "RGB"
+		c.Values, err = readComponents(r, 3)
+	case spaceCMYK:
+		c.Model = "CMYK"
+		c.Values, err = readComponents(r, 4)
+	case spaceLab:
+		c.Model = "LAB"
+		c.Values, err = readLab(r)
+	case spaceGrayscale:
+		c.Model = "Gray"
+		c.Values, err = readComponents(r, 1)
+	default:
+		err = ErrInvalidColorSpace
+	}
+	c.Type = "Spot"
+	return
+}
+
+func writeColor(w io.Writer, c ase.Color) (err error) {
+	if err = writeString(w, c.Name); err != nil {
+		return
+	}
+	var code [6]byte
+	if err = binary.Write(w, binary.BigEndian, code); err != nil {
+		return
+	}
+
+	switch c.Model {
+	case "LAB":
+		return writeLab(w, c.Values)
+	default:
+		return writeComponents(w, c.Values)
+	}
+}
+
+// commonColorSpace returns the single color space every color must share,
+// since a color book's colorSpace field applies to the whole book rather
+// than per entry. It's an error for colors to mix models.
+func commonColorSpace(colors []ase.Color) (uint16, error) {
+	if len(colors) == 0 {
+		return spaceRGB, nil
+	}
+
+	for _, c := range colors[1:] {
+		if c.Model != colors[0].Model {
+			return 0, ErrMixedColorSpace
+		}
+	}
+
+	switch colors[0].Model {
+	case "RGB":
+		return spaceRGB, nil
+	case "CMYK":
+		return spaceCMYK, nil
+	case "LAB":
+		return spaceLab, nil
+	case "Gray":
+		return spaceGrayscale, nil
+	}
+	return 0, ErrInvalidColorSpace
+}
+
+// readComponents reads n single-byte 0..255 channel values, scaled to this
+// package's 0..1 unit range.
+func readComponents(r io.Reader, n int) (values []float32, err error) {
+	raw := make([]byte, n)
+	if err = binary.Read(r, binary.BigEndian, &raw); err != nil {
+		return
+	}
+	values = make([]float32, n)
+	for i, v := range raw {
+		values[i] = float32(v) / 255
+	}
+	return
+}
+
+func writeComponents(w io.Writer, values []float32) error {
+	raw := make([]byte, len(values))
+	for i, v := range values {
+		raw[i] = byte(v * 255)
+	}
+	return binary.Write(w, binary.BigEndian, raw)
+}
+
+// ACB stores LAB as single bytes: L in 0..255 (mapping to 0..100) and a/b in
+// 0..255 (mapping to ±128), versus ase's L in 0..1 and a/b in ±128.
+func readLab(r io.Reader) (values []float32, err error) {
+	var raw [3]byte
+	if err = binary.Read(r, binary.BigEndian, &raw); err != nil {
+		return
+	}
+	l := float32(raw[0]) / 255
+	a := float32(raw[1]) - 128
+	b := float32(raw[2]) - 128
+	return []float32{l, a, b}, nil
+}
+
+func writeLab(w io.Writer, values []float32) error {
+	raw := [3]byte{
+		byte(values[0] * 255),
+		byte(values[1] + 128),
+		byte(values[2] + 128),
+	}
+	return binary.Write(w, binary.BigEndian, raw)
+}
+
+// readString reads a Pascal-style UTF-16BE string: a uint32 character count
+// (including a trailing NUL), followed by that many uint16 code units.
+func readString(r io.Reader) (s string, err error) {
+	var count uint32
+	if err = binary.Read(r, binary.BigEndian, &count); err != nil {
+		return
+	}
+	if count == 0 {
+		return "", nil
+	}
+
+	raw := make([]uint16, count)
+	if err = binary.Read(r, binary.BigEndian, &raw); err != nil {
+		return
+	}
+	raw = raw[:len(raw)-1] // drop the trailing NUL
+	return string(utf16.Decode(raw)), nil
+}
+
+func writeString(w io.Writer, s string) (err error) {
+	encoded := utf16.Encode([]rune(s))
+	encoded = append(encoded, 0)
+	if err = binary.Write(w, binary.BigEndian, uint32(len(encoded))); err != nil {
+		return
+	}
+	return binary.Write(w, binary.BigEndian, encoded)
+}
@@ -0,0 +1,66 @@
+package acb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/assassindesign/ase"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	in := ase.ASE{
+		Groups: []ase.Group{
+			{Name: "My Book", Colors: []ase.Color{
+				{Name: "Red", Model: "RGB", Values: []float32{1, 0, 0}, Type: "Spot"},
+				{Name: "Green", Model: "RGB", Values: []float32{0, 1, 0}, Type: "Spot"},
+			}},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := Encode(in, buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	out, err := Decode(buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if len(out.Groups) != 1 || out.Groups[0].Name != "My Book" {
+		t.Fatalf("Decode() = %+v", out)
+	}
+
+	want := in.Groups[0].Colors
+	got := out.Groups[0].Colors
+	if len(got) != len(want) {
+		t.Fatalf("Decode() got %d colors, want %d", len(got), len(want))
+	}
+
+	for i, w := range want {
+		if got[i].Name != w.Name || got[i].Model != w.Model {
+			t.Errorf("color %d: got {Name: %q, Model: %q}, want {Name: %q, Model: %q}",
+				i, got[i].Name, got[i].Model, w.Name, w.Model)
+		}
+		for j := range w.Values {
+			if diff := got[i].Values[j] - w.Values[j]; diff > 1e-2 || diff < -1e-2 {
+				t.Errorf("color %d value %d: got %v, want %v", i, j, got[i].Values[j], w.Values[j])
+			}
+		}
+	}
+}
+
+func TestEncodeRejectsMixedColorSpace(t *testing.T) {
+	in := ase.ASE{
+		Groups: []ase.Group{
+			{Name: "Mixed", Colors: []ase.Color{
+				{Name: "Red", Model: "RGB", Values: []float32{1, 0, 0}, Type: "Spot"},
+				{Name: "Cyan", Model: "CMYK", Values: []float32{1, 0, 0, 0}, Type: "Spot"},
+			}},
+		},
+	}
+
+	if err := Encode(in, new(bytes.Buffer)); err != ErrMixedColorSpace {
+		t.Fatalf("Encode() error = %v, want ErrMixedColorSpace", err)
+	}
+}
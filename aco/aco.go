@@ -0,0 +1,255 @@
+// Package aco decodes and encodes Photoshop Color Swatch (.aco) files,
+// sharing the Color and Group types from the parent ase package.
+//
+// ACO File Spec http://www.selapa.net/swatches/colors/fileformats.php#adobe_photoshop_color_swatch
+package aco
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"unicode/utf16"
+
+	"github.com/assassindesign/ase"
+)
+
+var (
+	ErrInvalidVersion    = errors.New("aco: version is not 1 or 2")
+	ErrInvalidColorSpace = errors.New("aco: unsupported color space")
+)
+
+// colorSpace ids, as used by Photoshop's ACO format.
+const (
+	spaceRGB       = 0
+	spaceCMYK      = 2
+	spaceLab       = 7
+	spaceGrayscale = 8
+)
+
+func init() {
+	// Every .aco file begins with a version 1 section (version == 1);
+	// a version 2 section with names may follow it, handled by Decode.
+	ase.RegisterFormat("\x00\x01", Decode)
+}
+
+// Decode reads a Photoshop .aco swatch file into an ase.ASE. A v1-only file
+// has no names; when a v2 section follows (the common case, and the only
+// one Photoshop itself writes), names are read from it and paired by
+// position with the v1 entries.
+func Decode(r io.Reader) (a ase.ASE, err error) {
+	v1, err := readVersion(r)
+	if err != nil {
+		return
+	}
+	if v1 != 1 {
+		err = ErrInvalidVersion
+		return
+	}
+
+	entries, err := readEntries(r, false)
+	if err != nil {
+		return
+	}
+
+	// A version 2 section, carrying names for the same entries, may
+	// follow. Its absence is not an error: the file is just unnamed.
+	v2, err := readVersion(r)
+	if err == nil && v2 == 2 {
+		named, err2 := readEntries(r, true)
+		if err2 != nil {
+			return a, err2
+		}
+		entries = named
+	}
+
+	for _, e := range entries {
+		a.Colors = append(a.Colors, e)
+	}
+	return a, nil
+}
+
+// Encode writes every top-level Color and grouped Color (groups are
+// flattened; ACO has no notion of nesting) from a as an .aco file: a
+// mandatory, unnamed version 1 section followed by a version 2 section
+// carrying the same entries' names, matching what Decode expects to read.
+func Encode(a ase.ASE, w io.Writer) (err error) {
+	colors := a.Colors
+	for _, g := range a.Groups {
+		colors = append(colors, g.Colors...)
+	}
+
+	if err = writeVersion(w, 1); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint16(len(colors))); err != nil {
+		return
+	}
+	for _, c := range colors {
+		if err = writeEntry(w, c, false); err != nil {
+			return
+		}
+	}
+
+	if err = writeVersion(w, 2); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, uint16(len(colors))); err != nil {
+		return
+	}
+	for _, c := range colors {
+		if err = writeEntry(w, c, true); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func readVersion(r io.Reader) (version uint16, err error) {
+	err = binary.Read(r, binary.BigEndian, &version)
+	return
+}
+
+func writeVersion(w io.Writer, version uint16) error {
+	return binary.Write(w, binary.BigEndian, version)
+}
+
+func readEntries(r io.Reader, named bool) (colors []ase.Color, err error) {
+	var count uint16
+	if err = binary.Read(r, binary.BigEndian, &count); err != nil {
+		return
+	}
+
+	colors = make([]ase.Color, count)
+	for i := range colors {
+		if colors[i], err = readEntry(r, named); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func readEntry(r io.Reader, named bool) (c ase.Color, err error) {
+	var space uint16
+	if err = binary.Read(r, binary.BigEndian, &space); err != nil {
+		return
+	}
+
+	var w, x, y, z uint16
+	if err = binary.Read(r, binary.BigEndian, &w); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &x); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &y); err != nil {
+		return
+	}
+	if err = binary.Read(r, binary.BigEndian, &z); err != nil {
+		return
+	}
+
+	switch space {
+	case spaceRGB:
+		c.Model = "RGB"
+		c.Values = []float32{component(w), component(x), component(y)}
+	case spaceCMYK:
+		c.Model = "CMYK"
+		c.Values = []float32{component(w), component(x), component(y), component(z)}
+	case spaceLab:
+		c.Model = "LAB"
+		c.Values = []float32{labL(w), labAB(x), labAB(y)}
+	case spaceGrayscale:
+		c.Model = "Gray"
+		c.Values = []float32{component(w)}
+	default:
+		err = ErrInvalidColorSpace
+		return
+	}
+	c.Type = "Global"
+
+	if named {
+		var nameLen uint32
+		if err = binary.Read(r, binary.BigEndian, &nameLen); err != nil {
+			return
+		}
+		raw := make([]uint16, nameLen)
+		if nameLen > 0 {
+			if err = binary.Read(r, binary.BigEndian, &raw); err != nil {
+				return
+			}
+			raw = raw[:len(raw)-1] // drop the trailing NUL
+		}
+		c.Name = string(utf16.Decode(raw))
+	}
+
+	return
+}
+
+func writeEntry(w io.Writer, c ase.Color, named bool) (err error) {
+	var space uint16
+	var v [4]uint16
+
+	switch c.Model {
+	case "RGB":
+		space = spaceRGB
+		v[0], v[1], v[2] = fromComponent(c.Values[0]), fromComponent(c.Values[1]), fromComponent(c.Values[2])
+	case "CMYK":
+		space = spaceCMYK
+		v[0], v[1], v[2], v[3] = fromComponent(c.Values[0]), fromComponent(c.Values[1]), fromComponent(c.Values[2]), fromComponent(c.Values[3])
+	case "LAB":
+		space = spaceLab
+		v[0], v[1], v[2] = fromLabL(c.Values[0]), fromLabAB(c.Values[1]), fromLabAB(c.Values[2])
+	case "Gray":
+		space = spaceGrayscale
+		v[0] = fromComponent(c.Values[0])
+	default:
+		return ErrInvalidColorSpace
+	}
+
+	if err = binary.Write(w, binary.BigEndian, space); err != nil {
+		return
+	}
+	for _, component := range v {
+		if err = binary.Write(w, binary.BigEndian, component); err != nil {
+			return
+		}
+	}
+
+	if named {
+		name := utf16.Encode([]rune(c.Name))
+		name = append(name, 0)
+		if err = binary.Write(w, binary.BigEndian, uint32(len(name))); err != nil {
+			return
+		}
+		err = binary.Write(w, binary.BigEndian, name)
+	}
+	return
+}
+
+// component converts an ACO 0..65535 channel value to this package's 0..1
+// unit scaling.
+func component(v uint16) float32 {
+	return float32(v) / 65535
+}
+
+func fromComponent(v float32) uint16 {
+	return uint16(v * 65535)
+}
+
+// ACO stores LAB as L in 0..10000 and a/b in ±12800, versus ase's L in 0..1
+// and a/b in ±128.
+func labL(v uint16) float32 {
+	return float32(int16(v)) / 10000
+}
+
+func labAB(v uint16) float32 {
+	return float32(int16(v)) / 100
+}
+
+func fromLabL(v float32) uint16 {
+	return uint16(int16(v * 10000))
+}
+
+func fromLabAB(v float32) uint16 {
+	return uint16(int16(v * 100))
+}
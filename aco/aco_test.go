@@ -0,0 +1,60 @@
+package aco
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/assassindesign/ase"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	in := ase.ASE{
+		Colors: []ase.Color{
+			{Name: "Red", Model: "RGB", Values: []float32{1, 0, 0}, Type: "Global"},
+		},
+		Groups: []ase.Group{
+			{Name: "Group", Colors: []ase.Color{
+				{Name: "Cyan", Model: "CMYK", Values: []float32{1, 0, 0, 0}, Type: "Global"},
+			}},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := Encode(in, buf); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	out, err := Decode(buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	want := append(append([]ase.Color{}, in.Colors...), in.Groups[0].Colors...)
+	if len(out.Colors) != len(want) {
+		t.Fatalf("Decode() got %d colors, want %d", len(out.Colors), len(want))
+	}
+
+	for i, w := range want {
+		g := out.Colors[i]
+		if g.Name != w.Name || g.Model != w.Model {
+			t.Errorf("color %d: got {Name: %q, Model: %q}, want {Name: %q, Model: %q}",
+				i, g.Name, g.Model, w.Name, w.Model)
+		}
+		for j := range w.Values {
+			if diff := g.Values[j] - w.Values[j]; diff > 1e-3 || diff < -1e-3 {
+				t.Errorf("color %d value %d: got %v, want %v", i, j, g.Values[j], w.Values[j])
+			}
+		}
+	}
+}
+
+func TestDecodeRequiresVersion1Section(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := writeVersion(buf, 2); err != nil {
+		t.Fatalf("writeVersion() error = %v", err)
+	}
+
+	if _, err := Decode(buf); err != ErrInvalidVersion {
+		t.Fatalf("Decode() error = %v, want ErrInvalidVersion", err)
+	}
+}
@@ -26,65 +26,53 @@ type ASE struct {
 
 //	Decodes a valid ASE input.
 func Decode(r io.Reader) (ase ASE, err error) {
-	if err = ase.readSignature(r); err != nil {
-		return
-	}
-	if err = ase.readVersion(r); err != nil {
-		return
-	}
-	if err = ase.readNumBlocks(r); err != nil {
+	d := NewDecoder(r)
+
+	var numBlocks int32
+	if _, _, _, numBlocks, err = d.Header(); err != nil {
 		return
 	}
+	ase.signature = d.ase.signature
+	ase.version = d.ase.version
+	ase.numBlocks = numBlocks
 
 	//	if we encounter groups, store a ref here
 	var g Group
+	inGroup := false
 
 	//	itereate based on our block count
-	for i := 0; i < int(ase.numBlocks); i++ {
-		//	new block
-		b := block{}
-
-		//	decode the block container
-		if err = b.Read(r); err != nil {
+	for i := int32(0); i < numBlocks; i++ {
+		b, blockErr := d.Next()
+		if blockErr != nil {
+			err = blockErr
 			return
 		}
 
 		//	switch on block type
-		switch b.Type {
-		case colorEntry:
-			c := Color{}
-			if err = c.read(r); err != nil {
-				return
-			}
-
+		switch {
+		case b.Color != nil:
 			//	if we have a group, add color to the group
-			if g.Name != "" {
-				g.Colors = append(g.Colors, c)
+			if inGroup {
+				g.Colors = append(g.Colors, b.Color.Color)
 			} else {
 				//	color is not in a group. add to color slice
-				ase.Colors = append(ase.Colors, c)
+				ase.Colors = append(ase.Colors, b.Color.Color)
 			}
-
-			break
-		case groupStart:
+		case b.GroupStart != nil:
 			//	new group
-			g = Group{}
-
-			//	read the group
-			if err = g.read(r); err != nil {
-				return
-			}
-
-			break
-		case groupEnd:
+			g = b.GroupStart.Group
+			inGroup = true
+		case b.GroupEnd != nil:
 			//	add the group to our ase struct
 			ase.Groups = append(ase.Groups, g)
 
 			//	reset our group struct
 			g = Group{}
-
-			break
+			inGroup = false
 		default:
+			//	Decode keeps its historical, strict contract: a block type
+			//	it doesn't recognize is an error. NewDecoder/Next, used
+			//	directly, surface it as an UnknownBlock instead.
 			err = ErrInvalidBlockType
 			return
 		}
@@ -0,0 +1,32 @@
+package ase
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// blockType identifies the kind of entry a block header precedes. Group
+// block codes (0xC001/0xC002) have the high bit set, so this must be
+// unsigned.
+type blockType uint16
+
+const (
+	colorEntry blockType = 0x0001
+	groupStart blockType = 0xC001
+	groupEnd   blockType = 0xC002
+)
+
+// block is the 6-byte header (type + body length) that precedes every
+// entry in an ASE file.
+type block struct {
+	Type   blockType
+	Length int32
+}
+
+// Read decodes a block header from r.
+func (b *block) Read(r io.Reader) (err error) {
+	if err = binary.Read(r, binary.BigEndian, &b.Type); err != nil {
+		return
+	}
+	return binary.Read(r, binary.BigEndian, &b.Length)
+}
@@ -0,0 +1,196 @@
+package ase
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/color"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// Color is a single named color entry, either floating at the top level of
+// an ASE or nested inside a Group.
+type Color struct {
+	Name   string
+	Model  string
+	Values []float32
+	Type   string
+}
+
+var colorTypeNames = map[int16]string{0: "Global", 1: "Spot", 2: "Normal"}
+var colorTypeCodes = map[string]int16{"Global": 0, "Spot": 1, "Normal": 2}
+
+// modelValueCount reports how many float32 components a color model stores.
+func modelValueCount(model string) int {
+	switch model {
+	case "RGB":
+		return 3
+	case "LAB":
+		return 3
+	case "CMYK":
+		return 4
+	case "Gray":
+		return 1
+	}
+	return 0
+}
+
+// read decodes a color entry's body. The caller is expected to have already
+// consumed the preceding block header.
+func (c *Color) read(r io.Reader) (err error) {
+	var nameLen uint16
+	if err = binary.Read(r, binary.BigEndian, &nameLen); err != nil {
+		return
+	}
+
+	raw := make([]uint16, nameLen)
+	if nameLen > 0 {
+		if err = binary.Read(r, binary.BigEndian, &raw); err != nil {
+			return
+		}
+		raw = raw[:len(raw)-1] // drop the trailing NUL
+	}
+	c.Name = string(utf16.Decode(raw))
+
+	model := make([]byte, 4)
+	if err = binary.Read(r, binary.BigEndian, &model); err != nil {
+		return
+	}
+	c.Model = strings.TrimRight(string(model), " ")
+
+	c.Values = make([]float32, modelValueCount(c.Model))
+	if err = binary.Read(r, binary.BigEndian, &c.Values); err != nil {
+		return
+	}
+
+	var t int16
+	if err = binary.Read(r, binary.BigEndian, &t); err != nil {
+		return
+	}
+	c.Type = colorTypeNames[t]
+
+	return
+}
+
+// write encodes a color entry, block header included, to w.
+func (c *Color) write(w io.Writer) (err error) {
+	body := new(bytes.Buffer)
+
+	name := utf16.Encode([]rune(c.Name))
+	name = append(name, 0)
+	if err = binary.Write(body, binary.BigEndian, uint16(len(name))); err != nil {
+		return
+	}
+	if err = binary.Write(body, binary.BigEndian, name); err != nil {
+		return
+	}
+
+	model := (c.Model + "    ")[:4]
+	if err = binary.Write(body, binary.BigEndian, []byte(model)); err != nil {
+		return
+	}
+
+	if err = binary.Write(body, binary.BigEndian, c.Values); err != nil {
+		return
+	}
+
+	if err = binary.Write(body, binary.BigEndian, colorTypeCodes[c.Type]); err != nil {
+		return
+	}
+
+	if err = binary.Write(w, binary.BigEndian, colorEntry); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, int32(body.Len())); err != nil {
+		return
+	}
+	_, err = w.Write(body.Bytes())
+	return
+}
+
+// NRGBA converts the Color to a non-alpha-premultiplied color.NRGBA,
+// converting CMYK (via color.CMYKToRGB) and LAB (via a D50 conversion, see
+// labToRGB) to sRGB as needed. ASE colors carry no transparency, so alpha is
+// always fully opaque.
+func (c Color) NRGBA() color.NRGBA {
+	switch c.Model {
+	case "RGB":
+		return color.NRGBA{
+			R: floatToByte(c.Values[0]),
+			G: floatToByte(c.Values[1]),
+			B: floatToByte(c.Values[2]),
+			A: 0xff,
+		}
+	case "CMYK":
+		r, g, b := color.CMYKToRGB(
+			floatToByte(c.Values[0]),
+			floatToByte(c.Values[1]),
+			floatToByte(c.Values[2]),
+			floatToByte(c.Values[3]),
+		)
+		return color.NRGBA{R: r, G: g, B: b, A: 0xff}
+	case "LAB":
+		r, g, b := labToRGB(c.Values[0], c.Values[1], c.Values[2])
+		return color.NRGBA{R: r, G: g, B: b, A: 0xff}
+	case "Gray":
+		v := floatToByte(c.Values[0])
+		return color.NRGBA{R: v, G: v, B: v, A: 0xff}
+	default:
+		return color.NRGBA{A: 0xff}
+	}
+}
+
+// RGBA implements color.Color, so a Color can be used anywhere the standard
+// library expects one (image.Paletted, GIF encoding, draw.Draw, ...).
+func (c Color) RGBA() (r, g, b, a uint32) {
+	return c.NRGBA().RGBA()
+}
+
+// floatToByte clamps a 0..1 ASE component to an 8-bit sRGB value.
+func floatToByte(v float32) uint8 {
+	if v < 0 {
+		v = 0
+	}
+	if v > 1 {
+		v = 1
+	}
+	return uint8(v*255 + 0.5)
+}
+
+// NewColorFromColor builds a Color named name from any color.Color,
+// picking the ASE model that best matches the source's own representation:
+// color.Gray/color.Gray16 become Gray, color.CMYK stays CMYK, and anything
+// else is converted to RGB via color.NRGBAModel.
+func NewColorFromColor(name string, c color.Color) Color {
+	switch v := c.(type) {
+	case color.Gray:
+		return Color{Name: name, Model: "Gray", Values: []float32{float32(v.Y) / 255}, Type: "Normal"}
+	case color.Gray16:
+		return Color{Name: name, Model: "Gray", Values: []float32{float32(v.Y) / 65535}, Type: "Normal"}
+	case color.CMYK:
+		return Color{
+			Name:  name,
+			Model: "CMYK",
+			Values: []float32{
+				float32(v.C) / 255,
+				float32(v.M) / 255,
+				float32(v.Y) / 255,
+				float32(v.K) / 255,
+			},
+			Type: "Normal",
+		}
+	default:
+		n := color.NRGBAModel.Convert(c).(color.NRGBA)
+		return Color{
+			Name:  name,
+			Model: "RGB",
+			Values: []float32{
+				float32(n.R) / 255,
+				float32(n.G) / 255,
+				float32(n.B) / 255,
+			},
+			Type: "Normal",
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package ase
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestColorNRGBA(t *testing.T) {
+	cases := []struct {
+		name string
+		c    Color
+		want color.NRGBA
+	}{
+		{"RGB", Color{Model: "RGB", Values: []float32{1, 0, 0}}, color.NRGBA{R: 255, G: 0, B: 0, A: 255}},
+		{"Gray", Color{Model: "Gray", Values: []float32{0.5}}, color.NRGBA{R: 128, G: 128, B: 128, A: 255}},
+		{"CMYK white", Color{Model: "CMYK", Values: []float32{0, 0, 0, 0}}, color.NRGBA{R: 255, G: 255, B: 255, A: 255}},
+	}
+
+	for _, tc := range cases {
+		if got := tc.c.NRGBA(); got != tc.want {
+			t.Errorf("%s: NRGBA() = %+v, want %+v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestColorRGBAImplementsColorColor(t *testing.T) {
+	c := Color{Model: "RGB", Values: []float32{1, 0, 0}}
+
+	r, g, b, a := c.RGBA()
+	wantR, wantG, wantB, wantA := (color.NRGBA{R: 255, A: 255}).RGBA()
+
+	if r != wantR || g != wantG || b != wantB || a != wantA {
+		t.Errorf("RGBA() = (%d,%d,%d,%d), want (%d,%d,%d,%d)", r, g, b, a, wantR, wantG, wantB, wantA)
+	}
+}
+
+func TestNewColorFromColorPicksModel(t *testing.T) {
+	if got := NewColorFromColor("red", color.NRGBA{R: 255, A: 255}); got.Model != "RGB" || got.Values[0] != 1 {
+		t.Errorf("RGB input: Model = %q, Values = %v", got.Model, got.Values)
+	}
+
+	if got := NewColorFromColor("mid-gray", color.Gray{Y: 128}); got.Model != "Gray" {
+		t.Errorf("Gray input: Model = %q, want Gray", got.Model)
+	}
+
+	cmyk := color.CMYK{C: 255, M: 0, Y: 0, K: 0}
+	if got := NewColorFromColor("cyan", cmyk); got.Model != "CMYK" || got.Values[0] != 1 {
+		t.Errorf("CMYK input: Model = %q, Values = %v", got.Model, got.Values)
+	}
+}
+
+func TestPaletteRoundTrip(t *testing.T) {
+	a := ASE{
+		Colors: []Color{
+			{Name: "Red", Model: "RGB", Values: []float32{1, 0, 0}, Type: "Normal"},
+		},
+		Groups: []Group{
+			{Name: "Group", Colors: []Color{
+				{Name: "Green", Model: "RGB", Values: []float32{0, 1, 0}, Type: "Normal"},
+			}},
+		},
+	}
+
+	p := a.Palette()
+	if len(p) != 2 {
+		t.Fatalf("Palette() len = %d, want 2", len(p))
+	}
+
+	rebuilt := NewFromPalette("Round Trip", p)
+	if len(rebuilt.Groups) != 1 || len(rebuilt.Groups[0].Colors) != 2 {
+		t.Fatalf("NewFromPalette() = %+v", rebuilt)
+	}
+
+	for i, want := range p {
+		wantR, wantG, wantB, _ := want.RGBA()
+		gotR, gotG, gotB, _ := rebuilt.Groups[0].Colors[i].RGBA()
+		if gotR != wantR || gotG != wantG || gotB != wantB {
+			t.Errorf("color %d: got (%d,%d,%d), want (%d,%d,%d)", i, gotR, gotG, gotB, wantR, wantG, wantB)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package ase
+
+import "math"
+
+// D50 reference white, matching the illuminant Adobe's swatch formats use
+// for LAB values.
+const (
+	labWhiteX = 0.9642
+	labWhiteY = 1.0000
+	labWhiteZ = 0.8249
+)
+
+func labForward(t float64) float64 {
+	const delta = 6.0 / 29.0
+	if t > delta*delta*delta {
+		return math.Cbrt(t)
+	}
+	return t/(3*delta*delta) + 4.0/29.0
+}
+
+func labInverse(t float64) float64 {
+	const delta = 6.0 / 29.0
+	if t > delta {
+		return t * t * t
+	}
+	return 3 * delta * delta * (t - 4.0/29.0)
+}
+
+func clampUnit(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
+
+// labToRGB converts CIE L*a*b* (L in 0..1, a/b in ±128, matching this
+// package's unit scaling) to 8-bit sRGB under a D50 white point.
+func labToRGB(l, a, b float32) (r, g, bl uint8) {
+	L := float64(l) * 100
+	fy := (L + 16) / 116
+	fx := fy + float64(a)/500
+	fz := fy - float64(b)/200
+
+	x := labWhiteX * labInverse(fx)
+	y := labWhiteY * labInverse(fy)
+	z := labWhiteZ * labInverse(fz)
+
+	// D50-adapted XYZ -> linear sRGB.
+	rl := 3.1338561*x - 1.6168667*y - 0.4906146*z
+	gl := -0.9787684*x + 1.9161415*y + 0.0334540*z
+	bll := 0.0719453*x - 0.2289914*y + 1.4052427*z
+
+	toByte := func(v float64) uint8 {
+		v = math.Pow(clampUnit(v), 1/2.2) * 255
+		return uint8(v + 0.5)
+	}
+
+	return toByte(rl), toByte(gl), toByte(bll)
+}
+
+// rgbToLab converts 8-bit sRGB to CIE L*a*b* under a D50 white point, with L
+// in 0..1 and a/b in ±128 to match this package's unit scaling.
+func rgbToLab(r, g, b uint8) (l, a, bb float32) {
+	lin := func(v uint8) float64 {
+		return math.Pow(float64(v)/255, 2.2)
+	}
+	rl, gl, bl := lin(r), lin(g), lin(b)
+
+	// Linear sRGB -> D50-adapted XYZ.
+	x := 0.4360747*rl + 0.3850649*gl + 0.1430804*bl
+	y := 0.2225045*rl + 0.7168786*gl + 0.0606169*bl
+	z := 0.0139322*rl + 0.0971045*gl + 0.7141733*bl
+
+	fx := labForward(x / labWhiteX)
+	fy := labForward(y / labWhiteY)
+	fz := labForward(z / labWhiteZ)
+
+	L := 116*fy - 16
+	A := 500 * (fx - fy)
+	B := 200 * (fy - fz)
+
+	return float32(L / 100), float32(A), float32(B)
+}
@@ -0,0 +1,118 @@
+package ase
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrInvalidBlockLength is returned by (*Decoder).Next when a block header
+// declares a negative or implausibly large body length, which a corrupt
+// file can do by setting the length field's high bit.
+var ErrInvalidBlockLength = errors.New("ase: invalid block length")
+
+// maxUnknownBlockLength bounds how large an UnknownBlock's raw body is
+// allowed to be, so a corrupt length field can't force an unbounded
+// allocation before the read even has a chance to fail.
+const maxUnknownBlockLength = 64 << 20 // 64 MiB
+
+// ColorBlock is a decoded color entry, at the top level or inside a group.
+type ColorBlock struct {
+	Color
+}
+
+// GroupStartBlock is a decoded group start entry. Colors that follow belong
+// to it until the matching GroupEndBlock.
+type GroupStartBlock struct {
+	Group
+}
+
+// GroupEndBlock closes the most recently opened GroupStartBlock.
+type GroupEndBlock struct{}
+
+// UnknownBlock preserves a block type this package doesn't recognize,
+// along with its raw body, so a forward-compatible file (e.g. a future ASE
+// revision adding metadata blocks) can still be read, inspected, and
+// re-encoded losslessly.
+type UnknownBlock struct {
+	Type blockType
+	Data []byte
+}
+
+// Block is the union Decoder.Next returns: exactly one field is set.
+type Block struct {
+	Color      *ColorBlock
+	GroupStart *GroupStartBlock
+	GroupEnd   *GroupEndBlock
+	Unknown    *UnknownBlock
+}
+
+// Decoder is a pull-style reader over an ASE file's blocks. Unlike Decode,
+// a truncated file or an unrecognized block doesn't discard whatever was
+// already parsed, and a caller can stop reading without materializing the
+// whole file (useful for very large palettes).
+type Decoder struct {
+	r    io.Reader
+	ase  ASE
+	read int32
+}
+
+// NewDecoder wraps r for block-by-block decoding. Call Header before Next.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// Header reads and returns the file's signature, version, and block count.
+func (d *Decoder) Header() (signature string, major, minor int16, numBlocks int32, err error) {
+	if err = d.ase.readSignature(d.r); err != nil {
+		return
+	}
+	if err = d.ase.readVersion(d.r); err != nil {
+		return
+	}
+	if err = d.ase.readNumBlocks(d.r); err != nil {
+		return
+	}
+
+	return d.ase.Signature(), d.ase.version[0], d.ase.version[1], d.ase.numBlocks, nil
+}
+
+// Next decodes and returns the following block. It returns io.EOF once
+// every block declared by Header has been read.
+func (d *Decoder) Next() (Block, error) {
+	if d.read >= d.ase.numBlocks {
+		return Block{}, io.EOF
+	}
+	d.read++
+
+	b := block{}
+	if err := b.Read(d.r); err != nil {
+		return Block{}, err
+	}
+
+	switch b.Type {
+	case colorEntry:
+		c := Color{}
+		if err := c.read(d.r); err != nil {
+			return Block{}, err
+		}
+		return Block{Color: &ColorBlock{c}}, nil
+	case groupStart:
+		g := Group{}
+		if err := g.read(d.r); err != nil {
+			return Block{}, err
+		}
+		return Block{GroupStart: &GroupStartBlock{g}}, nil
+	case groupEnd:
+		return Block{GroupEnd: &GroupEndBlock{}}, nil
+	default:
+		if b.Length < 0 || b.Length > maxUnknownBlockLength {
+			return Block{}, ErrInvalidBlockLength
+		}
+
+		data := make([]byte, b.Length)
+		if _, err := io.ReadFull(d.r, data); err != nil {
+			return Block{}, err
+		}
+		return Block{Unknown: &UnknownBlock{Type: b.Type, Data: data}}, nil
+	}
+}
@@ -0,0 +1,87 @@
+package ase
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+// writeHeader writes a minimal valid ASE header declaring numBlocks blocks.
+func writeHeader(buf *bytes.Buffer, numBlocks int32) {
+	buf.WriteString("ASEF")
+	binary.Write(buf, binary.BigEndian, [2]int16{1, 0})
+	binary.Write(buf, binary.BigEndian, numBlocks)
+}
+
+func TestDecoderRejectsCorruptBlockLength(t *testing.T) {
+	buf := new(bytes.Buffer)
+	writeHeader(buf, 1)
+	binary.Write(buf, binary.BigEndian, uint16(0x9999)) // unrecognized block type
+	binary.Write(buf, binary.BigEndian, int32(-1))      // corrupt (negative) length
+
+	d := NewDecoder(buf)
+	if _, _, _, _, err := d.Header(); err != nil {
+		t.Fatalf("Header() error = %v", err)
+	}
+
+	if _, err := d.Next(); err != ErrInvalidBlockLength {
+		t.Fatalf("Next() error = %v, want ErrInvalidBlockLength", err)
+	}
+}
+
+func TestDecoderRejectsOversizedBlockLength(t *testing.T) {
+	buf := new(bytes.Buffer)
+	writeHeader(buf, 1)
+	binary.Write(buf, binary.BigEndian, uint16(0x9999))
+	binary.Write(buf, binary.BigEndian, int32(maxUnknownBlockLength+1))
+
+	d := NewDecoder(buf)
+	if _, _, _, _, err := d.Header(); err != nil {
+		t.Fatalf("Header() error = %v", err)
+	}
+
+	if _, err := d.Next(); err != ErrInvalidBlockLength {
+		t.Fatalf("Next() error = %v, want ErrInvalidBlockLength", err)
+	}
+}
+
+func TestDecoderTruncatedUnknownBlockErrors(t *testing.T) {
+	buf := new(bytes.Buffer)
+	writeHeader(buf, 1)
+	binary.Write(buf, binary.BigEndian, uint16(0x9999))
+	binary.Write(buf, binary.BigEndian, int32(10)) // claims 10 bytes, but none follow
+
+	d := NewDecoder(buf)
+	if _, _, _, _, err := d.Header(); err != nil {
+		t.Fatalf("Header() error = %v", err)
+	}
+
+	if _, err := d.Next(); err != io.ErrUnexpectedEOF && err != io.EOF {
+		t.Fatalf("Next() error = %v, want an EOF-flavored error for a truncated block", err)
+	}
+}
+
+func TestDecoderUnknownBlockRoundTrip(t *testing.T) {
+	buf := new(bytes.Buffer)
+	writeHeader(buf, 1)
+	binary.Write(buf, binary.BigEndian, uint16(0x9999))
+	binary.Write(buf, binary.BigEndian, int32(4))
+	buf.Write([]byte{1, 2, 3, 4})
+
+	d := NewDecoder(buf)
+	if _, _, _, _, err := d.Header(); err != nil {
+		t.Fatalf("Header() error = %v", err)
+	}
+
+	b, err := d.Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if b.Unknown == nil {
+		t.Fatal("Next() block has no Unknown set")
+	}
+	if b.Unknown.Type != 0x9999 || !bytes.Equal(b.Unknown.Data, []byte{1, 2, 3, 4}) {
+		t.Errorf("Next() UnknownBlock = %+v", b.Unknown)
+	}
+}
@@ -0,0 +1,77 @@
+package ase
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// ErrBlockCountMismatch is returned by (*Encoder).Close when the number of
+// blocks written doesn't match the count declared to NewEncoder.
+var ErrBlockCountMismatch = errors.New("ase: wrote a different number of blocks than declared")
+
+// ErrEmptyBlock is returned by (*Encoder).WriteBlock when none of b's
+// fields is set.
+var ErrEmptyBlock = errors.New("ase: empty Block")
+
+// Encoder streams an ASE file one block at a time, so a caller can
+// generate a very large palette without materializing it in memory first.
+type Encoder struct {
+	w         io.Writer
+	numBlocks int32
+	written   int32
+}
+
+// NewEncoder wraps w for block-by-block encoding. numBlocks is the total
+// block count that will be written, and is recorded in the header.
+func NewEncoder(w io.Writer, numBlocks int32) *Encoder {
+	return &Encoder{w: w, numBlocks: numBlocks}
+}
+
+// WriteHeader writes the file's signature, version (always 1.0), and block
+// count. Call it once, before any WriteBlock.
+func (e *Encoder) WriteHeader() (err error) {
+	if err = binary.Write(e.w, binary.BigEndian, []byte("ASEF")); err != nil {
+		return
+	}
+	if err = binary.Write(e.w, binary.BigEndian, [2]int16{1, 0}); err != nil {
+		return
+	}
+	return binary.Write(e.w, binary.BigEndian, e.numBlocks)
+}
+
+// WriteBlock encodes a single block. Exactly one field of b must be set.
+func (e *Encoder) WriteBlock(b Block) (err error) {
+	switch {
+	case b.Color != nil:
+		err = b.Color.Color.write(e.w)
+	case b.GroupStart != nil:
+		err = b.GroupStart.Group.writeStart(e.w)
+	case b.GroupEnd != nil:
+		err = writeGroupEnd(e.w)
+	case b.Unknown != nil:
+		if err = binary.Write(e.w, binary.BigEndian, b.Unknown.Type); err != nil {
+			return
+		}
+		if err = binary.Write(e.w, binary.BigEndian, int32(len(b.Unknown.Data))); err != nil {
+			return
+		}
+		_, err = e.w.Write(b.Unknown.Data)
+	default:
+		return ErrEmptyBlock
+	}
+
+	if err == nil {
+		e.written++
+	}
+	return
+}
+
+// Close reports whether the number of blocks written matches the count
+// declared to NewEncoder.
+func (e *Encoder) Close() error {
+	if e.written != e.numBlocks {
+		return ErrBlockCountMismatch
+	}
+	return nil
+}
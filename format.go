@@ -0,0 +1,52 @@
+package ase
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// ErrUnknownFormat is returned by DecodeAuto when a reader's leading bytes
+// don't match any registered format.
+var ErrUnknownFormat = errors.New("ase: unknown format")
+
+type format struct {
+	magic  string
+	decode func(io.Reader) (ASE, error)
+}
+
+var formats []format
+
+// RegisterFormat registers a decoder for use by DecodeAuto, keyed on the
+// leading bytes (magic) a file of that format always starts with. This
+// mirrors image.RegisterFormat: it lets sibling codecs such as aco and acb
+// plug into DecodeAuto without this package importing them back, which
+// would be an import cycle since they import ase for its Color and Group
+// types. Callers that want DecodeAuto to recognize those formats must
+// blank-import the relevant package (e.g. `import _ ".../aco"`).
+func RegisterFormat(magic string, decode func(io.Reader) (ASE, error)) {
+	formats = append(formats, format{magic: magic, decode: decode})
+}
+
+func init() {
+	RegisterFormat("ASEF", Decode)
+}
+
+// DecodeAuto sniffs r's leading bytes against every registered format
+// (ASE's own "ASEF" signature is always available; aco and acb register
+// themselves on import) and decodes using the first match.
+func DecodeAuto(r io.Reader) (a ASE, err error) {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	for _, f := range formats {
+		b, peekErr := br.Peek(len(f.magic))
+		if peekErr == nil && string(b) == f.magic {
+			return f.decode(br)
+		}
+	}
+
+	return a, ErrUnknownFormat
+}
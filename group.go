@@ -0,0 +1,82 @@
+package ase
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"unicode/utf16"
+)
+
+// Group is a named collection of Colors, delimited in the underlying ASE
+// file by a groupStart block and a matching groupEnd block.
+type Group struct {
+	Name   string
+	Colors []Color
+}
+
+// read decodes a group start block's body (its name). Colors belonging to
+// the group are appended by the caller as subsequent blocks are read.
+func (g *Group) read(r io.Reader) (err error) {
+	var nameLen uint16
+	if err = binary.Read(r, binary.BigEndian, &nameLen); err != nil {
+		return
+	}
+
+	raw := make([]uint16, nameLen)
+	if nameLen > 0 {
+		if err = binary.Read(r, binary.BigEndian, &raw); err != nil {
+			return
+		}
+		raw = raw[:len(raw)-1] // drop the trailing NUL
+	}
+	g.Name = string(utf16.Decode(raw))
+
+	return
+}
+
+// write encodes a group's start block, its colors, and its end block to w.
+func (g *Group) write(w io.Writer) (err error) {
+	if err = g.writeStart(w); err != nil {
+		return
+	}
+
+	for _, c := range g.Colors {
+		if err = c.write(w); err != nil {
+			return
+		}
+	}
+
+	return writeGroupEnd(w)
+}
+
+// writeStart encodes just a group's start block (its name), letting a
+// caller interleave its own color writes before the matching groupEnd.
+func (g *Group) writeStart(w io.Writer) (err error) {
+	body := new(bytes.Buffer)
+
+	name := utf16.Encode([]rune(g.Name))
+	name = append(name, 0)
+	if err = binary.Write(body, binary.BigEndian, uint16(len(name))); err != nil {
+		return
+	}
+	if err = binary.Write(body, binary.BigEndian, name); err != nil {
+		return
+	}
+
+	if err = binary.Write(w, binary.BigEndian, groupStart); err != nil {
+		return
+	}
+	if err = binary.Write(w, binary.BigEndian, int32(body.Len())); err != nil {
+		return
+	}
+	_, err = w.Write(body.Bytes())
+	return
+}
+
+// writeGroupEnd encodes a (bodyless) group end block to w.
+func writeGroupEnd(w io.Writer) (err error) {
+	if err = binary.Write(w, binary.BigEndian, groupEnd); err != nil {
+		return
+	}
+	return binary.Write(w, binary.BigEndian, int32(0))
+}
@@ -0,0 +1,107 @@
+package ase
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ansiCode returns the "38;2;R;G;B" (foreground) or "48;2;R;G;B"
+// (background) SGR parameters for c, without the leading \x1b[ or
+// trailing m — the form LS_COLORS itself expects, since dircolors wraps
+// the escape around each value.
+func (c Color) ansiCode(foreground bool) string {
+	n := c.NRGBA()
+	prefix := "48"
+	if foreground {
+		prefix = "38"
+	}
+	return fmt.Sprintf("%s;2;%d;%d;%d", prefix, n.R, n.G, n.B)
+}
+
+// ANSI returns the 24-bit truecolor escape sequence for c: foreground
+// (\x1b[38;2;R;G;Bm) when foreground is true, background
+// (\x1b[48;2;R;G;Bm) otherwise.
+func (c Color) ANSI(foreground bool) string {
+	return "\x1b[" + c.ansiCode(foreground) + "m"
+}
+
+// WriteLSColors emits ase as an LS_COLORS-compatible key=value stream. A
+// top-level Color's Name is used as the key directly, matching dircolors'
+// two-letter keys ("di", "ex", "ln", ...) or glob patterns ("*.zip"); a
+// Group's Colors share the Group's Name as their key, so variants (e.g. a
+// normal and a bold "ln") can be collected under one entry.
+func (ase *ASE) WriteLSColors(w io.Writer) error {
+	var entries []string
+
+	for _, c := range ase.Colors {
+		entries = append(entries, c.Name+"="+c.ansiCode(true))
+	}
+	for _, g := range ase.Groups {
+		for _, c := range g.Colors {
+			entries = append(entries, g.Name+"="+c.ansiCode(true))
+		}
+	}
+
+	_, err := io.WriteString(w, strings.Join(entries, ":")+"\n")
+	return err
+}
+
+// ReadLSColors parses an LS_COLORS-style key=value stream into a single
+// Group named "LS_COLORS", with one RGB Color per recognized entry. Entries
+// whose value isn't a 24-bit truecolor SGR sequence are skipped.
+func ReadLSColors(r io.Reader) (a ASE, err error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return
+	}
+
+	group := Group{Name: "LS_COLORS"}
+	for _, entry := range strings.Split(strings.TrimSpace(string(data)), ":") {
+		if entry == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+
+		red, green, blue, ok := parseTrueColor(value)
+		if !ok {
+			continue
+		}
+
+		group.Colors = append(group.Colors, Color{
+			Name:   key,
+			Model:  "RGB",
+			Values: []float32{float32(red) / 255, float32(green) / 255, float32(blue) / 255},
+			Type:   "Normal",
+		})
+	}
+
+	a.Groups = append(a.Groups, group)
+	return a, nil
+}
+
+// parseTrueColor extracts R, G, B from a "...;38;2;R;G;B" or
+// "...;48;2;R;G;B" SGR parameter string, tolerating leading parameters
+// such as a bold "01;" prefix.
+func parseTrueColor(value string) (r, g, b uint8, ok bool) {
+	params := strings.Split(value, ";")
+	for i := 0; i+4 < len(params); i++ {
+		if (params[i] != "38" && params[i] != "48") || params[i+1] != "2" {
+			continue
+		}
+
+		rv, err1 := strconv.Atoi(params[i+2])
+		gv, err2 := strconv.Atoi(params[i+3])
+		bv, err3 := strconv.Atoi(params[i+4])
+		if err1 != nil || err2 != nil || err3 != nil {
+			return 0, 0, 0, false
+		}
+		return uint8(rv), uint8(gv), uint8(bv), true
+	}
+	return 0, 0, 0, false
+}
@@ -0,0 +1,88 @@
+package ase
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestColorANSI(t *testing.T) {
+	c := Color{Model: "RGB", Values: []float32{1, 0, 0}}
+
+	if got, want := c.ANSI(true), "\x1b[38;2;255;0;0m"; got != want {
+		t.Errorf("ANSI(true) = %q, want %q", got, want)
+	}
+	if got, want := c.ANSI(false), "\x1b[48;2;255;0;0m"; got != want {
+		t.Errorf("ANSI(false) = %q, want %q", got, want)
+	}
+}
+
+func TestWriteReadLSColorsRoundTrip(t *testing.T) {
+	a := ASE{
+		Colors: []Color{
+			{Name: "di", Model: "RGB", Values: []float32{0, 0, 1}, Type: "Normal"},
+		},
+		Groups: []Group{
+			{Name: "ln", Colors: []Color{
+				{Name: "normal", Model: "RGB", Values: []float32{0, 1, 1}, Type: "Normal"},
+			}},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := a.WriteLSColors(buf); err != nil {
+		t.Fatalf("WriteLSColors() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "di=38;2;0;0;255") {
+		t.Errorf("WriteLSColors() output %q missing expected \"di\" entry", out)
+	}
+	if !strings.Contains(out, "ln=38;2;0;255;255") {
+		t.Errorf("WriteLSColors() output %q missing expected \"ln\" entry", out)
+	}
+
+	got, err := ReadLSColors(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("ReadLSColors() error = %v", err)
+	}
+
+	if len(got.Groups) != 1 {
+		t.Fatalf("ReadLSColors() groups = %d, want 1", len(got.Groups))
+	}
+
+	byName := map[string]Color{}
+	for _, c := range got.Groups[0].Colors {
+		byName[c.Name] = c
+	}
+
+	di, ok := byName["di"]
+	if !ok {
+		t.Fatal("ReadLSColors() missing \"di\" entry")
+	}
+	if r, g, b, _ := di.RGBA(); r>>8 != 0 || g>>8 != 0 || b>>8 != 255 {
+		t.Errorf("\"di\" RGBA = (%d,%d,%d), want (0,0,255)", r>>8, g>>8, b>>8)
+	}
+
+	ln, ok := byName["ln"]
+	if !ok {
+		t.Fatal("ReadLSColors() missing \"ln\" entry")
+	}
+	if r, g, b, _ := ln.RGBA(); r>>8 != 0 || g>>8 != 255 || b>>8 != 255 {
+		t.Errorf("\"ln\" RGBA = (%d,%d,%d), want (0,255,255)", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestReadLSColorsSkipsUnrecognizedEntries(t *testing.T) {
+	a, err := ReadLSColors(strings.NewReader("rs=0:di=38;2;1;2;3"))
+	if err != nil {
+		t.Fatalf("ReadLSColors() error = %v", err)
+	}
+
+	if len(a.Groups) != 1 || len(a.Groups[0].Colors) != 1 {
+		t.Fatalf("ReadLSColors() = %+v, want exactly one recognized color", a)
+	}
+	if a.Groups[0].Colors[0].Name != "di" {
+		t.Errorf("ReadLSColors() kept %q, want \"di\"", a.Groups[0].Colors[0].Name)
+	}
+}
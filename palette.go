@@ -0,0 +1,36 @@
+package ase
+
+import (
+	"image/color"
+	"strconv"
+)
+
+// Palette flattens every top-level and grouped Color into a color.Palette,
+// in the order Colors then Groups (each group's Colors in turn) appear.
+// This lets an ASE's swatches feed straight into image.Paletted, GIF
+// encoding, or any other stdlib consumer of color.Palette.
+func (ase *ASE) Palette() color.Palette {
+	p := make(color.Palette, 0, len(ase.Colors))
+	for _, c := range ase.Colors {
+		p = append(p, c)
+	}
+	for _, g := range ase.Groups {
+		for _, c := range g.Colors {
+			p = append(p, c)
+		}
+	}
+	return p
+}
+
+// NewFromPalette builds a single-group ASE named name from a color.Palette,
+// storing every entry as an RGB Color.
+func NewFromPalette(name string, p color.Palette) ASE {
+	group := Group{Name: name}
+	for i, c := range p {
+		group.Colors = append(group.Colors, NewColorFromColor("Color "+strconv.Itoa(i+1), c))
+	}
+
+	ase := ASE{}
+	ase.Groups = append(ase.Groups, group)
+	return ase
+}